@@ -0,0 +1,270 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures exponential backoff with jitter, which statuses
+// are worth retrying, and optional per-host circuit breaking for
+// FetchWithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// BaseDelay is the backoff for the first retry; it doubles each
+	// attempt thereafter, up to MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, before jitter is applied.
+	MaxDelay time.Duration
+	// Breaker trips per host after too many consecutive failures. Nil
+	// disables circuit breaking.
+	Breaker *CircuitBreaker
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with sensible defaults: 3
+// attempts, starting at 500ms and capped at 30s, no circuit breaker.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+	}
+}
+
+// Response is the outcome of a successful FetchWithRetry call, or of one
+// that stopped on a non-retryable status, carrying enough detail for
+// downstream sinks to log or record it. Calls that use up their attempts,
+// meet an open circuit breaker, or are cancelled return a nil Response.
+type Response struct {
+	StatusCode int
+	Headers    http.Header
+	Body       string
+	Attempts   int
+	Latency    time.Duration
+}
+
+// FetchWithRetry performs an HTTP GET against target, retrying according to
+// policy: failures and retryable statuses (408, 425, 429, 5xx) back off
+// with full jitter between attempts, honoring a Retry-After header when
+// present; any other non-200 status is returned immediately as
+// non-retryable. The
+// request is cancelled promptly if ctx is done, instead of leaving the
+// caller blocked on a stuck read.
+func FetchWithRetry(ctx context.Context, target string, policy RetryPolicy) (*Response, error) {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy()
+	}
+
+	host := hostOf(target)
+	start := time.Now()
+
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if policy.Breaker != nil && !policy.Breaker.Allow(host) {
+			return nil, fmt.Errorf("circuit breaker open for %s", host)
+		}
+
+		status, headers, body, err := attemptGet(ctx, target)
+		if err == nil && status == http.StatusOK {
+			if policy.Breaker != nil {
+				policy.Breaker.RecordSuccess(host)
+			}
+			return &Response{
+				StatusCode: status,
+				Headers:    headers,
+				Body:       body,
+				Attempts:   attempt,
+				Latency:    time.Since(start),
+			}, nil
+		}
+
+		if policy.Breaker != nil {
+			policy.Breaker.RecordFailure(host)
+		}
+
+		if err != nil {
+			lastErr = err
+		} else if !shouldRetry(status) {
+			// A definitive failure (e.g. 404): no point retrying it.
+			return &Response{
+				StatusCode: status,
+				Headers:    headers,
+				Body:       body,
+				Attempts:   attempt,
+				Latency:    time.Since(start),
+			}, fmt.Errorf("non-retryable status %d", status)
+		} else {
+			lastErr = fmt.Errorf("retryable status %d", status)
+		}
+
+		if attempt == policy.MaxAttempts {
+			break
+		}
+
+		delay := ParseRetryAfter(headers)
+		if delay == 0 {
+			delay = jitteredBackoff(policy, attempt)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, fmt.Errorf("failed after %d attempts: %w", policy.MaxAttempts, lastErr)
+}
+
+// attemptGet issues a single cancellable GET request and fully reads and
+// closes the response body before returning, so retries never leak
+// connections across attempts.
+func attemptGet(ctx context.Context, target string) (status int, headers http.Header, body string, err error) {
+	resp, err := doGet(ctx, target)
+	if err != nil {
+		return 0, nil, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, resp.Header, "", fmt.Errorf("error reading body: %w", err)
+	}
+	return resp.StatusCode, resp.Header, string(data), nil
+}
+
+// shouldRetry classifies an HTTP status as worth retrying: 429 and any
+// 5xx, plus 408 (timeout) and 425 (too early). Other 4xx statuses are
+// treated as the caller's problem, not a transient one.
+func shouldRetry(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return status >= http.StatusInternalServerError
+}
+
+// jitteredBackoff returns an exponential backoff with full jitter:
+// sleep = rand() * min(MaxDelay, BaseDelay * 2^(attempt-1)).
+func jitteredBackoff(policy RetryPolicy, attempt int) time.Duration {
+	backoff := policy.BaseDelay * time.Duration(uint64(1)<<uint(attempt-1))
+	if backoff > policy.MaxDelay {
+		backoff = policy.MaxDelay
+	}
+	return time.Duration(rand.Float64() * float64(backoff))
+}
+
+// ParseRetryAfter reads a Retry-After header (delay-seconds or HTTP-date
+// form) and returns how long to wait, or 0 if absent/unparseable.
+func ParseRetryAfter(headers http.Header) time.Duration {
+	if headers == nil {
+		return 0
+	}
+	v := headers.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := time.ParseDuration(v + "s"); err == nil {
+		return secs
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// hostOf returns the host component of rawURL, or rawURL itself if it
+// doesn't parse, so callers always have some key to group breaker state by.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
+
+// CircuitBreaker trips open for a host after Threshold consecutive
+// failures, rejecting further requests until Cooldown has passed, at which
+// point it half-opens and allows one trial request through.
+type CircuitBreaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*breakerState
+}
+
+type breakerState struct {
+	consecutiveFailures int
+	open                bool
+	openedAt            time.Time
+}
+
+// NewCircuitBreaker returns a CircuitBreaker that trips after threshold
+// consecutive failures and half-opens after cooldown.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: threshold,
+		Cooldown:  cooldown,
+		hosts:     make(map[string]*breakerState),
+	}
+}
+
+// Allow reports whether a request to host may proceed.
+func (b *CircuitBreaker) Allow(host string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st, ok := b.hosts[host]
+	if !ok || !st.open {
+		return true
+	}
+	// Half-open: let a single trial request through once the cooldown
+	// has elapsed, restarting the cooldown so other callers keep being
+	// rejected; RecordSuccess/RecordFailure decide what happens next.
+	if time.Since(st.openedAt) < b.Cooldown {
+		return false
+	}
+	st.openedAt = time.Now()
+	return true
+}
+
+// RecordSuccess resets host's failure count and closes its breaker.
+func (b *CircuitBreaker) RecordSuccess(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := b.stateFor(host)
+	st.consecutiveFailures = 0
+	st.open = false
+}
+
+// RecordFailure increments host's consecutive failure count, tripping the
+// breaker open once Threshold is reached.
+func (b *CircuitBreaker) RecordFailure(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	st := b.stateFor(host)
+	st.consecutiveFailures++
+	if st.consecutiveFailures >= b.Threshold {
+		st.open = true
+		st.openedAt = time.Now()
+	}
+}
+
+func (b *CircuitBreaker) stateFor(host string) *breakerState {
+	st, ok := b.hosts[host]
+	if !ok {
+		st = &breakerState{}
+		b.hosts[host] = st
+	}
+	return st
+}
@@ -0,0 +1,58 @@
+package fetcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker(3, time.Hour)
+	host := "example.com"
+
+	for i := 0; i < 3; i++ {
+		if !b.Allow(host) {
+			t.Fatalf("failure %d: expected Allow before the threshold is reached", i)
+		}
+		b.RecordFailure(host)
+	}
+
+	if b.Allow(host) {
+		t.Fatal("expected breaker to be open after threshold consecutive failures")
+	}
+}
+
+func TestCircuitBreakerHalfOpensAfterCooldown(t *testing.T) {
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+	host := "example.com"
+
+	b.RecordFailure(host)
+	if b.Allow(host) {
+		t.Fatal("expected breaker open immediately after tripping")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !b.Allow(host) {
+		t.Fatal("expected breaker to half-open once the cooldown has elapsed")
+	}
+}
+
+func TestCircuitBreakerRecordSuccessClosesAndRecordFailureReopens(t *testing.T) {
+	b := NewCircuitBreaker(1, 10*time.Millisecond)
+	host := "example.com"
+
+	b.RecordFailure(host)
+	time.Sleep(20 * time.Millisecond)
+	if !b.Allow(host) {
+		t.Fatal("expected breaker to half-open once the cooldown has elapsed")
+	}
+
+	b.RecordSuccess(host)
+	if !b.Allow(host) {
+		t.Fatal("expected breaker to stay closed after a successful trial request")
+	}
+
+	b.RecordFailure(host)
+	if b.Allow(host) {
+		t.Fatal("expected a fresh failure to reopen the breaker immediately")
+	}
+}
@@ -3,10 +3,17 @@ package main
 import (
 	"bufio"
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
+	"time"
 
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/fetcher"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/output"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/policy"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/queue"
 	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/worker"
 )
 
@@ -14,52 +21,134 @@ import (
 // that will process URLs from the jobs channel.
 const workerCount = 5
 
+// maxCrawlDepth bounds how many hops past the seed URLs a crawl will
+// follow discovered links.
+const maxCrawlDepth = 2
+
+// sinkBatchSize and sinkFlushInterval control how often the file-backed
+// output sinks flush to disk.
+const (
+	sinkBatchSize     = 50
+	sinkFlushInterval = time.Second
+)
+
+// outputFlag selects where results are written. Accepted forms: "stdout"
+// (the default), "jsonl:<path>", "csv:<path>", or "sqlite:<path>".
+var outputFlag = flag.String("output", "stdout", `where to write results: "stdout", "jsonl:<path>", "csv:<path>", or "sqlite:<path>"`)
+
+// newOutputSink builds the ResultSink named by an --output flag value.
+func newOutputSink(spec string) (queue.ResultSink, error) {
+	kind, path, hasPath := strings.Cut(spec, ":")
+
+	switch kind {
+	case "stdout":
+		return output.NewStdoutSink(), nil
+	case "jsonl":
+		if !hasPath {
+			return nil, fmt.Errorf("--output jsonl requires a path, e.g. jsonl:results.jsonl")
+		}
+		return output.NewJSONLSink(path, sinkBatchSize, sinkFlushInterval)
+	case "csv":
+		if !hasPath {
+			return nil, fmt.Errorf("--output csv requires a path, e.g. csv:results.csv")
+		}
+		return output.NewCSVSink(path, sinkBatchSize, sinkFlushInterval)
+	case "sqlite":
+		if !hasPath {
+			return nil, fmt.Errorf("--output sqlite requires a path, e.g. sqlite:results.db")
+		}
+		return output.NewSQLiteSink(path, sinkBatchSize, sinkFlushInterval)
+	default:
+		return nil, fmt.Errorf("unknown --output kind %q", kind)
+	}
+}
+
 func main() {
-	// Create a buffered channel to hold jobs (URLs to scrape).
-	// The buffer size of 10 allows sending jobs without blocking immediately
-	// if workers are temporarily busy.
-	jobs := make(chan string, 10)
+	flag.Parse()
+
+	sink, err := newOutputSink(*outputFlag)
+	if err != nil {
+		fmt.Println("Invalid --output:", err)
+		os.Exit(1)
+	}
+	defer sink.Close()
 
-	// Create a buffered channel for results from workers.
-	// Workers will push either success or error messages here.
-	results := make(chan string, 10)
+	// q is the job/result queue. It's an in-memory one here; worker.NewPool
+	// only depends on the queue.JobSource/ResultSink interfaces, so the pool
+	// can be handed queue.NewKafkaSource/queue.NewSQSSource instead. The
+	// results loop below reads q.Results(), which only the in-memory queue
+	// provides.
+	q := queue.NewMemory(100, 10)
 
 	// Create a context with cancellation to allow graceful shutdown.
-	// When "cancel" is called, all workers listening to this context will stop.
 	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-	// Start a pool of worker goroutines.
-	// Each worker runs the worker.Start function and listens for jobs on the "jobs" channel.
-	for i := 0; i < workerCount; i++ {
-		go worker.Start(ctx, i, jobs, results)
+	cfg := worker.Config{
+		MaxDepth:     maxCrawlDepth,
+		SameHostOnly: true,
+		Policy: policy.NewHostPolicy(policy.HostPolicyConfig{
+			QPS:            1,
+			Burst:          2,
+			MaxConcurrency: 2,
+			RespectRobots:  true,
+		}),
+		RetryPolicy: fetcher.RetryPolicy{
+			MaxAttempts: 4,
+			BaseDelay:   500 * time.Millisecond,
+			MaxDelay:    30 * time.Second,
+			Breaker:     fetcher.NewCircuitBreaker(5, 30*time.Second),
+		},
 	}
 
-	// Graceful shutdown handler (runs in a separate goroutine).
-	// Listens for an OS interrupt signal (e.g. Ctrl+C) and then cancels the context.
+	// pool owns the worker goroutines. Unlike the old loose
+	// goroutine-plus-channel setup, it tracks every worker with a
+	// WaitGroup, so results is only closed once all of them have actually
+	// exited instead of leaking on shutdown.
+	pool := worker.NewPool(ctx, workerCount, q, q, cfg)
+
+	// Graceful shutdown handler (runs in a separate goroutine). The first
+	// Ctrl+C asks the pool to drain: finish whatever's already queued. A
+	// second Ctrl+C aborts immediately, cancelling in-flight fetches.
 	go func() {
-		c := make(chan os.Signal, 1) // Channel to receive interrupt signals.
+		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt)
-		<-c // Wait until an interrupt signal is received.
-		fmt.Println("Shutting down...")
-		cancel() // Cancel the context to signal all workers to stop.
+
+		<-c
+		fmt.Println("Shutting down (draining queued jobs; press Ctrl+C again to abort)...")
+		pool.Close(worker.Drain)
+
+		<-c
+		fmt.Println("Aborting...")
+		pool.Close(worker.Abort)
 	}()
 
-	// Feed jobs (URLs) into the jobs channel in a separate goroutine.
-	// This reads URLs from "urls.txt" and sends them to workers.
+	// Feed seed jobs (URLs) into the pool in a separate goroutine.
+	// This reads URLs from "urls.txt" and submits them at depth 0.
 	go func() {
 		file, _ := os.Open("urls.txt") // Open the file containing the URLs.
 		defer file.Close()
 
 		scanner := bufio.NewScanner(file) // Create a scanner to read the file line by line.
 		for scanner.Scan() {
-			jobs <- scanner.Text() // Send each URL to the jobs channel.
+			pool.Submit(queue.Job{URL: scanner.Text(), Depth: 0}) // Seed each URL into the pool.
 		}
-		close(jobs) // Close the jobs channel after all URLs are sent.
+		q.CloseJobs() // No more seed URLs will ever be enqueued.
 	}()
 
-	// Main goroutine reads results from the results channel and prints them.
-	// This loop will keep running until results are no longer being sent.
-	for res := range results {
-		fmt.Println(res)
+	// Close the results channel only once every worker has exited, so the
+	// range loop below is guaranteed to end instead of hanging forever.
+	go func() {
+		pool.Wait()
+		q.Close()
+	}()
+
+	// Main goroutine reads results from the queue and hands them to the
+	// configured output sink. This loop will keep running until results
+	// are no longer being sent.
+	for res := range q.Results() {
+		if err := sink.Publish(ctx, res); err != nil {
+			fmt.Printf("output: %v\n", err)
+		}
 	}
 }
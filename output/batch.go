@@ -0,0 +1,78 @@
+// Package output provides ResultSink implementations that write scraped
+// pages to a destination: stdout, JSON Lines, CSV, or SQLite.
+package output
+
+import (
+	"context"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// batcher drains a channel of *parser.ScrapeResult on a single goroutine,
+// calling flush whenever batchSize results have accumulated or
+// flushInterval elapses, whichever comes first. Centralizing this means
+// every file-backed sink gets one writer goroutine and no locking, so
+// concurrent Publish calls from many workers never contend on file I/O.
+type batcher struct {
+	ch   chan *parser.ScrapeResult
+	done chan struct{}
+}
+
+func newBatcher(bufferSize, batchSize int, flushInterval time.Duration, flush func([]*parser.ScrapeResult)) *batcher {
+	b := &batcher{
+		ch:   make(chan *parser.ScrapeResult, bufferSize),
+		done: make(chan struct{}),
+	}
+	go b.run(batchSize, flushInterval, flush)
+	return b
+}
+
+func (b *batcher) run(batchSize int, flushInterval time.Duration, flush func([]*parser.ScrapeResult)) {
+	defer close(b.done)
+
+	batch := make([]*parser.ScrapeResult, 0, batchSize)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	drain := func() {
+		if len(batch) == 0 {
+			return
+		}
+		flush(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case result, ok := <-b.ch:
+			if !ok {
+				drain()
+				return
+			}
+			batch = append(batch, result)
+			if len(batch) >= batchSize {
+				drain()
+			}
+
+		case <-ticker.C:
+			drain()
+		}
+	}
+}
+
+func (b *batcher) publish(ctx context.Context, result *parser.ScrapeResult) error {
+	select {
+	case b.ch <- result:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close signals the writer goroutine to flush whatever remains and stop,
+// and waits for it to do so.
+func (b *batcher) close() {
+	close(b.ch)
+	<-b.done
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// csvHeader is the fixed column order written to every CSV sink.
+var csvHeader = []string{"url", "status", "title", "links"}
+
+// CSVSink is a ResultSink that appends each result as a CSV row to a file,
+// flushing in batches rather than on every write so high worker counts
+// don't contend on file I/O.
+type CSVSink struct {
+	file *os.File
+	b    *batcher
+}
+
+// NewCSVSink opens path (creating or truncating it), writes the CSV header,
+// and returns a CSVSink that batches up to batchSize records, or
+// flushInterval of elapsed time, before writing them out.
+func NewCSVSink(path string, batchSize int, flushInterval time.Duration) (*CSVSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("csv sink: %w", err)
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.Write(csvHeader); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("csv sink: write header: %w", err)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("csv sink: write header: %w", err)
+	}
+
+	s := &CSVSink{file: f}
+	s.b = newBatcher(batchSize*2, batchSize, flushInterval, func(batch []*parser.ScrapeResult) {
+		for _, result := range batch {
+			row := []string{
+				result.URL,
+				strconv.Itoa(result.Status),
+				result.Fields["title"],
+				strconv.Itoa(len(result.Links)),
+			}
+			if err := w.Write(row); err != nil {
+				fmt.Printf("csv sink: write: %v\n", err)
+			}
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			fmt.Printf("csv sink: flush: %v\n", err)
+		}
+	})
+	return s, nil
+}
+
+// Publish implements queue.ResultSink.
+func (s *CSVSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	return s.b.publish(ctx, result)
+}
+
+// Close implements queue.ResultSink. It flushes any buffered rows and
+// closes the underlying file.
+func (s *CSVSink) Close() error {
+	s.b.close()
+	return s.file.Close()
+}
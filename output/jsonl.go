@@ -0,0 +1,57 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// JSONLSink is a ResultSink that appends each result as a line of JSON to a
+// file, flushing in batches rather than on every write.
+type JSONLSink struct {
+	file *os.File
+	b    *batcher
+}
+
+// NewJSONLSink opens path (creating or truncating it) and returns a
+// JSONLSink that batches up to batchSize records, or flushInterval of
+// elapsed time, before writing them out.
+func NewJSONLSink(path string, batchSize int, flushInterval time.Duration) (*JSONLSink, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("jsonl sink: %w", err)
+	}
+
+	s := &JSONLSink{file: f}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+
+	s.b = newBatcher(batchSize*2, batchSize, flushInterval, func(batch []*parser.ScrapeResult) {
+		for _, result := range batch {
+			if err := enc.Encode(result); err != nil {
+				fmt.Printf("jsonl sink: encode: %v\n", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			fmt.Printf("jsonl sink: flush: %v\n", err)
+		}
+	})
+	return s, nil
+}
+
+// Publish implements queue.ResultSink.
+func (s *JSONLSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	return s.b.publish(ctx, result)
+}
+
+// Close implements queue.ResultSink. It flushes any buffered records and
+// closes the underlying file.
+func (s *JSONLSink) Close() error {
+	s.b.close()
+	return s.file.Close()
+}
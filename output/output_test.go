@@ -0,0 +1,105 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+type publisher interface {
+	Publish(ctx context.Context, result *parser.ScrapeResult) error
+}
+
+func publishConcurrently(t *testing.T, sink publisher, n int) {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result := &parser.ScrapeResult{URL: "https://example.com/page", Status: 200}
+			if err := sink.Publish(context.Background(), result); err != nil {
+				t.Errorf("publish %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestJSONLSinkRecordCountUnderConcurrentLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.jsonl")
+
+	sink, err := NewJSONLSink(path, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewJSONLSink: %v", err)
+	}
+
+	const want = 200
+	publishConcurrently(t, sink, want)
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	got := countLines(t, path)
+	if got != want {
+		t.Fatalf("got %d lines, want %d", got, want)
+	}
+}
+
+func TestCSVSinkRecordCountUnderConcurrentLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.csv")
+
+	sink, err := NewCSVSink(path, 10, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewCSVSink: %v", err)
+	}
+
+	const want = 200
+	publishConcurrently(t, sink, want)
+
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+
+	got := len(rows) - 1 // minus header
+	if got != want {
+		t.Fatalf("got %d rows, want %d", got, want)
+	}
+}
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	n := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		n++
+	}
+	return n
+}
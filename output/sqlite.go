@@ -0,0 +1,83 @@
+package output
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteSink is a ResultSink that inserts each result into a SQLite table,
+// one transaction per batch rather than one transaction per record, so
+// high worker counts don't contend on the database.
+type SQLiteSink struct {
+	db *sql.DB
+	b  *batcher
+}
+
+// NewSQLiteSink opens (creating if necessary) the SQLite database at path,
+// ensures its results table exists, and returns a SQLiteSink that batches
+// up to batchSize records, or flushInterval of elapsed time, per
+// transaction.
+func NewSQLiteSink(path string, batchSize int, flushInterval time.Duration) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite sink: open: %w", err)
+	}
+
+	const schema = `CREATE TABLE IF NOT EXISTS results (
+		url    TEXT NOT NULL,
+		status INTEGER NOT NULL,
+		title  TEXT,
+		links  INTEGER NOT NULL
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sqlite sink: create table: %w", err)
+	}
+
+	s := &SQLiteSink{db: db}
+	s.b = newBatcher(batchSize*2, batchSize, flushInterval, func(batch []*parser.ScrapeResult) {
+		if err := s.insertBatch(batch); err != nil {
+			fmt.Printf("sqlite sink: insert batch: %v\n", err)
+		}
+	})
+	return s, nil
+}
+
+func (s *SQLiteSink) insertBatch(batch []*parser.ScrapeResult) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT INTO results (url, status, title, links) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, result := range batch {
+		if _, err := stmt.Exec(result.URL, result.Status, result.Fields["title"], len(result.Links)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Publish implements queue.ResultSink.
+func (s *SQLiteSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	return s.b.publish(ctx, result)
+}
+
+// Close implements queue.ResultSink. It flushes any buffered records and
+// closes the database.
+func (s *SQLiteSink) Close() error {
+	s.b.close()
+	return s.db.Close()
+}
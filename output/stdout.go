@@ -0,0 +1,24 @@
+package output
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// StdoutSink is a ResultSink that prints a one-line summary of each result
+// (URL, status, title, link count) to stdout.
+type StdoutSink struct{}
+
+// NewStdoutSink returns a StdoutSink.
+func NewStdoutSink() *StdoutSink { return &StdoutSink{} }
+
+// Publish implements queue.ResultSink.
+func (StdoutSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	fmt.Printf("%s [%d]: title=%q, %d links\n", result.URL, result.Status, result.Fields["title"], len(result.Links))
+	return nil
+}
+
+// Close implements queue.ResultSink.
+func (StdoutSink) Close() error { return nil }
@@ -0,0 +1,107 @@
+// Package parser turns a fetched HTML page into structured data: its title,
+// any caller-configured CSS-selector fields, and the links it points to so a
+// crawler can decide what to visit next.
+package parser
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ScrapeResult is the structured outcome of fetching and parsing a single
+// page, replacing the plain strings the worker pool used to report.
+type ScrapeResult struct {
+	URL     string
+	Status  int
+	Headers map[string][]string
+	Fields  map[string]string
+	Links   []string
+}
+
+// Extractor turns a fetched HTML body into a ScrapeResult. Callers can
+// supply their own implementation to pull out different fields; the worker
+// pool only depends on this interface, not on how extraction works.
+type Extractor interface {
+	Extract(pageURL string, status int, headers map[string][]string, body []byte) (*ScrapeResult, error)
+}
+
+// Selector names a single field to pull out of a page via a CSS selector.
+// Attr is empty to take the matched element's text content, or set to an
+// attribute name (e.g. "href", "content") to take that attribute instead.
+type Selector struct {
+	Name string
+	CSS  string
+	Attr string
+}
+
+// DefaultExtractor is the built-in Extractor: it always discovers <a href>
+// links and the page title, plus whatever Selectors the caller configures.
+type DefaultExtractor struct {
+	Selectors []Selector
+}
+
+// NewDefaultExtractor returns an Extractor that discovers links and the page
+// title, and additionally extracts the given CSS-selector fields.
+func NewDefaultExtractor(selectors ...Selector) *DefaultExtractor {
+	return &DefaultExtractor{Selectors: selectors}
+}
+
+// Extract implements Extractor.
+func (e *DefaultExtractor) Extract(pageURL string, status int, headers map[string][]string, body []byte) (*ScrapeResult, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ScrapeResult{
+		URL:     pageURL,
+		Status:  status,
+		Headers: headers,
+		Fields:  make(map[string]string),
+	}
+
+	if title := strings.TrimSpace(doc.Find("title").First().Text()); title != "" {
+		result.Fields["title"] = title
+	}
+
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, ok := s.Attr("href")
+		if !ok {
+			return
+		}
+		abs, err := base.Parse(href)
+		if err != nil {
+			return
+		}
+		abs.Fragment = ""
+		link := abs.String()
+		if !seen[link] {
+			seen[link] = true
+			result.Links = append(result.Links, link)
+		}
+	})
+
+	for _, sel := range e.Selectors {
+		node := doc.Find(sel.CSS).First()
+		if node.Length() == 0 {
+			continue
+		}
+		if sel.Attr != "" {
+			if v, ok := node.Attr(sel.Attr); ok {
+				result.Fields[sel.Name] = v
+			}
+			continue
+		}
+		result.Fields[sel.Name] = strings.TrimSpace(node.Text())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"context"
+	"time"
+)
+
+// AllowAll is a Policy that never throttles and never consults robots.txt.
+// It's the default when a worker pool isn't configured with one, keeping
+// the unthrottled behavior of earlier versions available as an opt-in.
+type AllowAll struct{}
+
+// Wait implements Policy.
+func (AllowAll) Wait(ctx context.Context, host string) (func(), error) {
+	return func() {}, nil
+}
+
+// Allowed implements Policy.
+func (AllowAll) Allowed(rawURL string) bool { return true }
+
+// Update implements Policy.
+func (AllowAll) Update(host string, retryAfter time.Duration) {}
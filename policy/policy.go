@@ -0,0 +1,172 @@
+// Package policy implements polite-crawling controls: per-host concurrency
+// caps, token-bucket rate limits, and robots.txt enforcement. Workers block
+// on these limits instead of firing requests a host is going to drop.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+	"golang.org/x/time/rate"
+)
+
+// Policy governs how politely the crawler behaves towards a given host.
+// Workers call Wait before every fetch to throttle, Allowed to respect
+// robots.txt, and Update to feed back rate-limiting hints a response
+// carried. Implementations must be safe for concurrent use by many workers,
+// and custom ones (e.g. a Redis-backed limiter shared across a distributed
+// worker fleet) can be substituted for HostPolicy.
+type Policy interface {
+	// Wait blocks until a request to host may proceed, respecting the
+	// host's concurrency cap and rate limit, or returns ctx.Err() if ctx
+	// is canceled first. The caller must invoke the returned done func
+	// once the request completes, freeing its concurrency slot.
+	Wait(ctx context.Context, host string) (done func(), err error)
+
+	// Allowed reports whether rawURL may be fetched under its host's
+	// robots.txt rules.
+	Allowed(rawURL string) bool
+
+	// Update feeds back a Crawl-Delay or Retry-After hint observed for
+	// host, slowing its rate limit down to match until it elapses.
+	Update(host string, retryAfter time.Duration)
+}
+
+// HostPolicyConfig configures a HostPolicy.
+type HostPolicyConfig struct {
+	// QPS is the steady-state requests-per-second allowed per host.
+	QPS float64
+	// Burst is the number of requests that may fire back-to-back before
+	// QPS throttling kicks in.
+	Burst int
+	// MaxConcurrency caps how many in-flight requests a single host may
+	// have at once.
+	MaxConcurrency int
+	// RespectRobots enables fetching and enforcing robots.txt per host.
+	RespectRobots bool
+	// UserAgent is matched against robots.txt user-agent groups.
+	UserAgent string
+	// Client fetches robots.txt. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// HostPolicy is the default Policy: a token-bucket rate limiter and a
+// concurrency semaphore per host, plus an optional robots.txt check.
+type HostPolicy struct {
+	cfg HostPolicyConfig
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	limiter *rate.Limiter
+	sem     chan struct{}
+
+	robotsOnce sync.Once
+	robots     *robotstxt.RobotsData
+}
+
+// NewHostPolicy returns a HostPolicy applying cfg's limits to every host it
+// sees requests for.
+func NewHostPolicy(cfg HostPolicyConfig) *HostPolicy {
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "go-concurrent-web-scraper"
+	}
+	return &HostPolicy{cfg: cfg, hosts: make(map[string]*hostState)}
+}
+
+func (p *HostPolicy) state(host string) *hostState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	st, ok := p.hosts[host]
+	if !ok {
+		concurrency := p.cfg.MaxConcurrency
+		if concurrency <= 0 {
+			concurrency = 1
+		}
+		st = &hostState{
+			limiter: rate.NewLimiter(rate.Limit(p.cfg.QPS), p.cfg.Burst),
+			sem:     make(chan struct{}, concurrency),
+		}
+		p.hosts[host] = st
+	}
+	return st
+}
+
+// Wait implements Policy.
+func (p *HostPolicy) Wait(ctx context.Context, host string) (func(), error) {
+	st := p.state(host)
+
+	if err := st.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	select {
+	case st.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	return func() { <-st.sem }, nil
+}
+
+// Allowed implements Policy.
+func (p *HostPolicy) Allowed(rawURL string) bool {
+	if !p.cfg.RespectRobots {
+		return true
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true
+	}
+
+	st := p.state(u.Host)
+	st.robotsOnce.Do(func() {
+		st.robots = p.fetchRobots(u)
+	})
+	if st.robots == nil {
+		return true
+	}
+	return st.robots.TestAgent(u.Path, p.cfg.UserAgent)
+}
+
+// Update implements Policy. The slowdown is temporary: once retryAfter has
+// elapsed, the host's limiter is restored to its configured QPS rather
+// than staying crippled for the rest of the run.
+func (p *HostPolicy) Update(host string, retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		return
+	}
+	st := p.state(host)
+	st.limiter.SetLimit(rate.Every(retryAfter))
+	time.AfterFunc(retryAfter, func() {
+		st.limiter.SetLimit(rate.Limit(p.cfg.QPS))
+	})
+}
+
+func (p *HostPolicy) fetchRobots(u *url.URL) *robotstxt.RobotsData {
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", u.Scheme, u.Host)
+
+	resp, err := p.cfg.Client.Get(robotsURL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	data, err := robotstxt.FromResponse(resp)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,19 @@
+package queue
+
+import (
+	"time"
+)
+
+// backoffDelay returns an exponential backoff duration for the given retry
+// attempt count (1 = first retry), capped at 60s, used by backends that
+// requeue a job after a failure instead of dropping it.
+func backoffDelay(attempts int) time.Duration {
+	if attempts >= 6 {
+		return 60 * time.Second
+	}
+	d := time.Duration(1) << uint(attempts)
+	if d > 60 {
+		d = 60
+	}
+	return d * time.Second
+}
@@ -0,0 +1,183 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSourceConfig configures a KafkaSource.
+type KafkaSourceConfig struct {
+	Brokers []string
+	Topic   string
+	GroupID string
+}
+
+// KafkaSource is a JobSource backed by a Kafka consumer group: it
+// subscribes to a topic of URLs and only commits a message's offset once
+// its fetch+process has succeeded, so a crash mid-fetch redelivers the job
+// to another member of the group instead of losing it.
+type KafkaSource struct {
+	reader *kafka.Reader
+	writer *kafka.Writer
+
+	mu      sync.Mutex
+	pending map[string]kafka.Message
+}
+
+// NewKafkaSource returns a KafkaSource consuming cfg.Topic as part of
+// consumer group cfg.GroupID, and producing back onto the same topic for
+// Enqueue/Nack.
+func NewKafkaSource(cfg KafkaSourceConfig) *KafkaSource {
+	return &KafkaSource{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: cfg.Brokers,
+			Topic:   cfg.Topic,
+			GroupID: cfg.GroupID,
+		}),
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(cfg.Brokers...),
+			Topic:    cfg.Topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+		pending: make(map[string]kafka.Message),
+	}
+}
+
+// Next implements JobSource. It fetches but does not commit the message,
+// so the offset only advances once Ack is called.
+func (s *KafkaSource) Next(ctx context.Context) (Job, bool, error) {
+	msg, err := s.reader.FetchMessage(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return Job{}, false, nil
+		}
+		return Job{}, false, fmt.Errorf("kafka: fetch message: %w", err)
+	}
+
+	var job Job
+	if err := json.Unmarshal(msg.Value, &job); err != nil {
+		return Job{}, false, fmt.Errorf("kafka: decode job: %w", err)
+	}
+
+	// Keyed by partition+offset, not job identity: consumer-group
+	// redelivery can legitimately hand out two different messages for
+	// the same URL/depth before either is Ack'd, and those must not
+	// collide in s.pending.
+	job.deliveryTag = deliveryTag(msg)
+
+	s.mu.Lock()
+	s.pending[job.deliveryTag] = msg
+	s.mu.Unlock()
+
+	return job, true, nil
+}
+
+// Enqueue implements JobSource by producing job back onto the topic.
+func (s *KafkaSource) Enqueue(ctx context.Context, job Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("kafka: encode job: %w", err)
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{Value: data})
+}
+
+// Ack implements JobSource by committing job's offset.
+func (s *KafkaSource) Ack(ctx context.Context, job Job) error {
+	msg, ok := s.takePending(job)
+	if !ok {
+		return nil
+	}
+	return s.reader.CommitMessages(ctx, msg)
+}
+
+// Nack implements JobSource. It re-produces the job after an exponential
+// backoff and commits the original message's offset, so a failing job
+// retries without stalling the rest of its partition.
+func (s *KafkaSource) Nack(ctx context.Context, job Job) error {
+	msg, ok := s.takePending(job)
+	if !ok {
+		return nil
+	}
+
+	job.Attempts++
+	select {
+	case <-time.After(backoffDelay(job.Attempts)):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if err := s.Enqueue(ctx, job); err != nil {
+		return err
+	}
+	return s.reader.CommitMessages(ctx, msg)
+}
+
+// CloseJobs implements JobSource. A Kafka topic has no concept of "no
+// more external producers" - other writers may still publish to it - so
+// there's nothing to signal. On this backend a Drain never makes Next
+// report exhaustion; workers keep consuming until their context is
+// canceled.
+func (s *KafkaSource) CloseJobs() error { return nil }
+
+// Close implements JobSource.
+func (s *KafkaSource) Close() error {
+	readerErr := s.reader.Close()
+	writerErr := s.writer.Close()
+	if readerErr != nil {
+		return readerErr
+	}
+	return writerErr
+}
+
+func (s *KafkaSource) takePending(job Job) (kafka.Message, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg, ok := s.pending[job.deliveryTag]
+	if ok {
+		delete(s.pending, job.deliveryTag)
+	}
+	return msg, ok
+}
+
+// deliveryTag identifies a specific Kafka delivery, unique per
+// partition+offset even if the same job is redelivered.
+func deliveryTag(msg kafka.Message) string {
+	return fmt.Sprintf("%d/%d", msg.Partition, msg.Offset)
+}
+
+// KafkaSink is a ResultSink that publishes each ScrapeResult as JSON to a
+// Kafka topic.
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaSink returns a KafkaSink producing to topic on brokers.
+func NewKafkaSink(brokers []string, topic string) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:     kafka.TCP(brokers...),
+			Topic:    topic,
+			Balancer: &kafka.LeastBytes{},
+		},
+	}
+}
+
+// Publish implements ResultSink.
+func (s *KafkaSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("kafka: encode result: %w", err)
+	}
+	return s.writer.WriteMessages(ctx, kafka.Message{Value: data})
+}
+
+// Close implements ResultSink.
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
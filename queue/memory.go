@@ -0,0 +1,118 @@
+package queue
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// Memory is the default JobSource/ResultSink: the buffered in-memory
+// channels the scraper has always used, wrapped behind the pluggable
+// interfaces so other backends are drop-in replacements.
+//
+// Workers are producers on the jobs channel too - they feed discovered
+// links back in via Enqueue - so closing it can't simply happen the
+// moment the seed feeder is done. pending tracks every job that's been
+// enqueued but not yet Ack'd/Nack'd (including links a worker may still
+// be about to enqueue for a job it hasn't retired yet), and the jobs
+// channel is only actually closed once the feeder has signalled it's
+// done *and* pending has drained to zero.
+type Memory struct {
+	jobs    chan Job
+	results chan *parser.ScrapeResult
+
+	pending    sync.WaitGroup
+	feederDone chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewMemory returns a Memory queue with the given channel buffer sizes.
+func NewMemory(jobsBuffer, resultsBuffer int) *Memory {
+	m := &Memory{
+		jobs:       make(chan Job, jobsBuffer),
+		results:    make(chan *parser.ScrapeResult, resultsBuffer),
+		feederDone: make(chan struct{}),
+	}
+	go m.closeJobsWhenDrained()
+	return m
+}
+
+// closeJobsWhenDrained waits for the seed feeder to finish and every
+// outstanding job to be retired before closing jobs, so a worker's
+// Enqueue of a newly discovered link can never race with the close.
+func (m *Memory) closeJobsWhenDrained() {
+	<-m.feederDone
+	m.pending.Wait()
+	close(m.jobs)
+}
+
+// Results returns the channel callers should range over to consume
+// published results.
+func (m *Memory) Results() <-chan *parser.ScrapeResult { return m.results }
+
+// CloseJobs implements JobSource, signalling that no more seed jobs will
+// be enqueued from outside. The jobs channel itself is only closed once
+// every job already in the system - including any links workers are
+// still discovering - has been Ack'd or Nack'd.
+func (m *Memory) CloseJobs() error {
+	m.closeOnce.Do(func() { close(m.feederDone) })
+	return nil
+}
+
+// Next implements JobSource.
+func (m *Memory) Next(ctx context.Context) (Job, bool, error) {
+	select {
+	case job, ok := <-m.jobs:
+		return job, ok, nil
+	case <-ctx.Done():
+		return Job{}, false, nil
+	}
+}
+
+// Enqueue implements JobSource.
+func (m *Memory) Enqueue(ctx context.Context, job Job) error {
+	m.pending.Add(1)
+	select {
+	case m.jobs <- job:
+		return nil
+	case <-ctx.Done():
+		m.pending.Done()
+		return ctx.Err()
+	}
+}
+
+// Ack implements JobSource. A channel has no external commit state to
+// reconcile, so this just retires job's pending credit.
+func (m *Memory) Ack(ctx context.Context, job Job) error {
+	m.pending.Done()
+	return nil
+}
+
+// Nack implements JobSource by re-enqueueing job and only then retiring its
+// old pending credit. Enqueue-then-Done (rather than the other way around)
+// matters here: if this is the last outstanding job, Done-then-Enqueue
+// would let pending transiently hit zero and let closeJobsWhenDrained's
+// Wait return before the retry's Add ever happens.
+func (m *Memory) Nack(ctx context.Context, job Job) error {
+	job.Attempts++
+	err := m.Enqueue(ctx, job)
+	m.pending.Done()
+	return err
+}
+
+// Publish implements ResultSink.
+func (m *Memory) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	select {
+	case m.results <- result:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close implements both JobSource and ResultSink.
+func (m *Memory) Close() error {
+	close(m.results)
+	return nil
+}
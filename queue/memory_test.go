@@ -0,0 +1,91 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryClosesJobsOnlyAfterPendingDrains(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(1, 1)
+
+	if err := m.Enqueue(ctx, Job{URL: "https://example.com/a"}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	m.CloseJobs()
+
+	job, ok, err := m.Next(ctx)
+	if err != nil || !ok {
+		t.Fatalf("Next: job=%v ok=%v err=%v, want the enqueued job", job, ok, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		if _, ok, _ := m.Next(ctx); ok {
+			t.Error("expected Next to report exhaustion, not another job")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("jobs channel closed before the only in-flight job was Ack'd")
+	case <-time.After(50 * time.Millisecond):
+		// Still blocked, as expected: pending hasn't drained yet.
+	}
+
+	if err := m.Ack(ctx, job); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("jobs channel never closed after the only pending job was Ack'd")
+	}
+}
+
+func TestMemoryNackRequeuesLastJobBeforeClosing(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory(1, 1)
+
+	seed := Job{URL: "https://example.com/a"}
+	if err := m.Enqueue(ctx, seed); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	m.CloseJobs()
+
+	job, ok, err := m.Next(ctx)
+	if err != nil || !ok {
+		t.Fatalf("Next: job=%v ok=%v err=%v, want the seeded job", job, ok, err)
+	}
+
+	if err := m.Nack(ctx, job); err != nil {
+		t.Fatalf("Nack: %v", err)
+	}
+
+	// The retry must be receivable, not a closed channel - Nack is the
+	// last outstanding credit, so this is where a premature close would
+	// surface.
+	retried, ok, err := m.Next(ctx)
+	if err != nil || !ok {
+		t.Fatalf("Next after Nack: job=%v ok=%v err=%v, want the requeued job", retried, ok, err)
+	}
+	if retried.URL != seed.URL {
+		t.Fatalf("got requeued job %q, want %q", retried.URL, seed.URL)
+	}
+
+	if err := m.Ack(ctx, retried); err != nil {
+		t.Fatalf("Ack: %v", err)
+	}
+
+	select {
+	case _, ok := <-m.jobs:
+		if ok {
+			t.Fatal("expected jobs channel to be closed after the requeued job was Ack'd")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("jobs channel never closed")
+	}
+}
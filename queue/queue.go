@@ -0,0 +1,76 @@
+// Package queue abstracts the transport that jobs (URLs to crawl) arrive on
+// and results (scraped pages) are published to, so the worker pool can run
+// against an in-memory channel, a Kafka topic, or an SQS queue without any
+// change to its own logic.
+package queue
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+)
+
+// ErrEmpty is returned by Next when no job is available right now but the
+// source is not exhausted - e.g. an SQS long-poll that simply timed out
+// with nothing to receive. It means "nothing to do yet, call Next again",
+// not "stop"; ok is still false, since no job was returned.
+var ErrEmpty = errors.New("queue: no job available right now")
+
+// Job is a single crawl unit: a URL to fetch and the depth it was
+// discovered at, relative to the seed URLs which start at depth 0.
+type Job struct {
+	URL      string
+	Depth    int
+	Attempts int // how many times this job has been Nacked and requeued; SQS redeliveries keep the count the message was sent with
+
+	// deliveryTag identifies this specific delivery of job for Ack/Nack
+	// bookkeeping on backends with at-least-once, possibly-duplicate
+	// delivery (Kafka, SQS). It's set by JobSource.Next and is backend-
+	// internal: unexported, so it never round-trips through the wire
+	// encoding of a re-enqueued job.
+	deliveryTag string
+}
+
+// JobSource is the input side of a work queue: it hands out jobs and lets
+// the worker pool feed back newly discovered links and commit/retry
+// decisions once a job is done.
+type JobSource interface {
+	// Next returns the next job to process. ok is false once the source
+	// is exhausted (e.g. an in-memory channel was closed) and the caller
+	// should stop; err is ErrEmpty when nothing is available right now
+	// but more jobs may still arrive (e.g. an SQS long-poll came back
+	// empty), and the caller should call Next again. Any other non-nil
+	// err is a transport failure, not exhaustion or ctx cancellation.
+	Next(ctx context.Context) (job Job, ok bool, err error)
+
+	// Enqueue schedules job for processing, e.g. a link discovered while
+	// crawling a page.
+	Enqueue(ctx context.Context, job Job) error
+
+	// Ack marks job as successfully processed, letting the backend commit
+	// its offset or delete its message.
+	Ack(ctx context.Context, job Job) error
+
+	// Nack marks job as failed, letting the backend requeue it according
+	// to its own retry/backoff policy.
+	Nack(ctx context.Context, job Job) error
+
+	// CloseJobs signals that no more jobs will be submitted from outside
+	// the pool (i.e. no further Pool.Submit calls), so a drain can
+	// actually finish: workers keep processing whatever's already
+	// queued or in-flight, and Next eventually reports exhaustion.
+	// Backends with no concept of an external producer set (Kafka, SQS -
+	// other writers may still publish) treat this as a no-op.
+	CloseJobs() error
+
+	// Close releases any underlying connections.
+	Close() error
+}
+
+// ResultSink is the output side of the pipeline: it publishes a scraped
+// page somewhere downstream (a channel, a topic, a table).
+type ResultSink interface {
+	Publish(ctx context.Context, result *parser.ScrapeResult) error
+	Close() error
+}
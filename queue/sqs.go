@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// sqsLongPollTimeout bounds a single receive call so a network stall can't
+// hang a worker on a "stuck receive" forever.
+const sqsLongPollTimeout = 30 * time.Second
+
+// SQSSource is a JobSource backed by an SQS queue. Acks delete the message;
+// Nacks set its visibility timeout to backoffDelay(job.Attempts+1) so it
+// reappears for redelivery instead of being dropped. A Nack does not
+// rewrite the message body, so Attempts does not grow across redeliveries
+// and the delay stays at its first-retry value.
+type SQSSource struct {
+	client   *sqs.Client
+	queueURL string
+
+	mu      sync.Mutex
+	pending map[string]struct{} // set of outstanding receipt handles
+}
+
+// NewSQSSource returns an SQSSource polling queueURL via client.
+func NewSQSSource(client *sqs.Client, queueURL string) *SQSSource {
+	return &SQSSource{
+		client:   client,
+		queueURL: queueURL,
+		pending:  make(map[string]struct{}),
+	}
+}
+
+// Next implements JobSource with a bounded long-poll: the receive is
+// wrapped in its own 30s timeout derived from ctx, so it can never block
+// longer than that even if SQS itself hangs.
+func (s *SQSSource) Next(ctx context.Context) (Job, bool, error) {
+	pollCtx, cancel := context.WithTimeout(ctx, sqsLongPollTimeout)
+	defer cancel()
+
+	out, err := s.client.ReceiveMessage(pollCtx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(s.queueURL),
+		MaxNumberOfMessages: 1,
+		WaitTimeSeconds:     20,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return Job{}, false, nil
+		}
+		// The bounded poll timing out just means nothing arrived in time,
+		// not that the queue is exhausted; the caller should poll again.
+		if pollCtx.Err() != nil {
+			return Job{}, false, ErrEmpty
+		}
+		return Job{}, false, fmt.Errorf("sqs: receive message: %w", err)
+	}
+	if len(out.Messages) == 0 {
+		return Job{}, false, ErrEmpty
+	}
+
+	msg := out.Messages[0]
+	var job Job
+	if err := json.Unmarshal([]byte(aws.ToString(msg.Body)), &job); err != nil {
+		return Job{}, false, fmt.Errorf("sqs: decode job: %w", err)
+	}
+
+	// Keyed by receipt handle, not job identity: SQS's at-least-once
+	// delivery can legitimately hand out two messages for the same
+	// URL/depth before either is Ack'd, each with its own handle, and
+	// those must not collide in s.pending.
+	job.deliveryTag = aws.ToString(msg.ReceiptHandle)
+
+	s.mu.Lock()
+	s.pending[job.deliveryTag] = struct{}{}
+	s.mu.Unlock()
+
+	return job, true, nil
+}
+
+// Enqueue implements JobSource by sending a new message.
+func (s *SQSSource) Enqueue(ctx context.Context, job Job) error {
+	body, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("sqs: encode job: %w", err)
+	}
+	_, err = s.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(s.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
+
+// Ack implements JobSource by deleting the message.
+func (s *SQSSource) Ack(ctx context.Context, job Job) error {
+	handle, ok := s.takePending(job)
+	if !ok {
+		return nil
+	}
+	_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(s.queueURL),
+		ReceiptHandle: aws.String(handle),
+	})
+	return err
+}
+
+// Nack implements JobSource by shortening the message's visibility timeout
+// to backoffDelay(job.Attempts+1) so it becomes receivable again after a
+// delay instead of waiting out the full default timeout. Only the
+// visibility changes, not the body, so a redelivered job carries the same
+// Attempts and each Nack of it waits the same delay.
+func (s *SQSSource) Nack(ctx context.Context, job Job) error {
+	handle, ok := s.takePending(job)
+	if !ok {
+		return nil
+	}
+
+	job.Attempts++
+	_, err := s.client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          aws.String(s.queueURL),
+		ReceiptHandle:     aws.String(handle),
+		VisibilityTimeout: int32(backoffDelay(job.Attempts).Seconds()),
+	})
+	return err
+}
+
+// CloseJobs implements JobSource. An SQS queue has no concept of "no more
+// external producers" - other senders may still publish to it - so
+// there's nothing to signal. On this backend a Drain never makes Next
+// report exhaustion; workers keep polling until their context is canceled.
+func (s *SQSSource) CloseJobs() error { return nil }
+
+// Close implements JobSource. SQS has no long-lived connection to tear down.
+func (s *SQSSource) Close() error { return nil }
+
+func (s *SQSSource) takePending(job Job) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[job.deliveryTag]; !ok {
+		return "", false
+	}
+	delete(s.pending, job.deliveryTag)
+	return job.deliveryTag, true
+}
+
+// SQSSink is a ResultSink that sends each ScrapeResult as a JSON message to
+// an SQS queue.
+type SQSSink struct {
+	client   *sqs.Client
+	queueURL string
+}
+
+// NewSQSSink returns an SQSSink sending to queueURL via client.
+func NewSQSSink(client *sqs.Client, queueURL string) *SQSSink {
+	return &SQSSink{client: client, queueURL: queueURL}
+}
+
+// Publish implements ResultSink.
+func (s *SQSSink) Publish(ctx context.Context, result *parser.ScrapeResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("sqs: encode result: %w", err)
+	}
+	_, err = s.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(s.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
+
+// Close implements ResultSink.
+func (s *SQSSink) Close() error { return nil }
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/queue"
+)
+
+// ShutdownMode controls how Pool.Close behaves.
+type ShutdownMode int
+
+const (
+	// Drain lets every worker finish whatever src still has queued before
+	// stopping. Close calls src.CloseJobs and nothing more: Submit still
+	// forwards jobs afterwards, and workers exit on their own only once src
+	// reports exhaustion, which queue.Memory does after every pending job is
+	// retired and the Kafka and SQS sources never do.
+	Drain ShutdownMode = iota
+	// Abort cancels the workers' context immediately, interrupting any
+	// in-flight fetch rather than letting it finish.
+	Abort
+)
+
+// Pool runs a fixed number of workers pulling jobs from a JobSource and
+// publishing results to a ResultSink. It replaces the old pattern of main
+// looping to spawn worker.Start goroutines directly and closing channels
+// itself - a pattern that leaked workers on shutdown because results was
+// never closed and the range loop over it hung forever.
+type Pool struct {
+	src queue.JobSource
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	visited sync.Map
+}
+
+// NewPool starts n workers against src/sink under ctx and returns
+// immediately; the workers run until the pool is closed and drained.
+func NewPool(ctx context.Context, n int, src queue.JobSource, sink queue.ResultSink, cfg Config) *Pool {
+	workerCtx, cancel := context.WithCancel(ctx)
+	p := &Pool{src: src, ctx: workerCtx, cancel: cancel}
+
+	p.wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(id int) {
+			defer p.wg.Done()
+			Start(p.ctx, id, src, sink, cfg, &p.visited)
+		}(i)
+	}
+	return p
+}
+
+// Submit enqueues job for processing.
+func (p *Pool) Submit(job queue.Job) error {
+	return p.src.Enqueue(p.ctx, job)
+}
+
+// Close begins shutting the pool down according to mode. It does not block;
+// call Wait to know when every worker has actually exited.
+func (p *Pool) Close(mode ShutdownMode) {
+	switch mode {
+	case Drain:
+		// Tell src no more jobs will be submitted (Submit itself does not
+		// refuse them); workers finish whatever src already has queued or
+		// in-flight and exit once it reports exhaustion.
+		if err := p.src.CloseJobs(); err != nil {
+			fmt.Printf("[Pool] Error closing job source: %v\n", err)
+		}
+	case Abort:
+		p.cancel()
+	}
+}
+
+// Wait blocks until every worker has exited, then releases the pool's
+// context. Safe to call only once.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+	p.cancel()
+}
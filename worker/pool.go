@@ -3,49 +3,184 @@ package worker
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sync"
 
 	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/fetcher"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/parser"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/policy"
+	"github.com/Chukwuemeka-James/go-concurrent-web-scraper/queue"
 )
 
-// Start launches a worker goroutine that continuously listens for jobs (URLs) on the 'jobs' channel.
-// Each worker fetches the content of the URL using fetcher.FetchWithRetry and sends the result
-// or error message into the 'results' channel.
-// The worker stops gracefully when either:
+// Config controls how a worker parses pages and how far it follows the
+// links it discovers on them.
+type Config struct {
+	// MaxDepth is the deepest a discovered link may be scheduled at.
+	// Seed URLs are depth 0; MaxDepth 0 disables link discovery entirely.
+	MaxDepth int
+
+	// Extractor parses each fetched page into a ScrapeResult and
+	// discovers its outbound links. Defaults to parser.NewDefaultExtractor()
+	// when nil.
+	Extractor parser.Extractor
+
+	// SameHostOnly restricts discovered links to the host of the page
+	// they were found on, keeping the crawl in scope.
+	SameHostOnly bool
+
+	// Policy enforces per-host concurrency/rate limits and robots.txt
+	// before each fetch. Defaults to policy.AllowAll{} when nil.
+	Policy policy.Policy
+
+	// RetryPolicy configures each fetch's backoff, per-status retry
+	// decisions, and circuit breaker. Defaults to
+	// fetcher.DefaultRetryPolicy() when its MaxAttempts is 0.
+	RetryPolicy fetcher.RetryPolicy
+}
+
+// Start runs a worker loop, in the calling goroutine, that continuously
+// pulls jobs (URLs) from src. Each worker fetches the page, extracts structured data and
+// links from it, publishes a ScrapeResult to sink, and - up to cfg.MaxDepth
+// - enqueues any newly discovered in-scope links back onto src. The worker
+// stops gracefully when either:
 //  1. The context is canceled (graceful shutdown).
-//  2. The jobs channel is closed (no more URLs to process).
+//  2. src is exhausted (no more jobs will ever arrive).
+//  3. src.Next returns an error other than queue.ErrEmpty.
 //
 // Parameters:
 //   - ctx: Context used to handle cancellation for graceful shutdown.
 //   - id: Unique identifier for the worker (useful for logging/debugging).
-//   - jobs: A read-only channel from which the worker receives URLs to process.
-//   - results: A write-only channel where the worker sends results or error messages.
-func Start(ctx context.Context, id int, jobs <-chan string, results chan<- string) {
+//   - src: Where jobs are pulled from and discovered links are fed back to;
+//     may be an in-memory queue, a Kafka consumer group, or an SQS queue.
+//   - sink: Where parsed pages are published.
+//   - cfg: Parsing and crawl-depth configuration.
+//   - visited: Shared canonical-URL guard so a page is only scraped once,
+//     no matter how many workers discover a link to it.
+func Start(ctx context.Context, id int, src queue.JobSource, sink queue.ResultSink, cfg Config, visited *sync.Map) {
+	extractor := cfg.Extractor
+	if extractor == nil {
+		extractor = parser.NewDefaultExtractor()
+	}
+	pol := cfg.Policy
+	if pol == nil {
+		pol = policy.AllowAll{}
+	}
+	retryPolicy := cfg.RetryPolicy
+	if retryPolicy.MaxAttempts == 0 {
+		retryPolicy = fetcher.DefaultRetryPolicy()
+	}
+
 	for {
-		select {
-		// Case 1: Listen for cancellation signal from context
-		case <-ctx.Done():
-			// If the context is canceled (e.g., user pressed Ctrl+C),
-			// the worker logs a stop message and exits the loop.
+		job, ok, err := src.Next(ctx)
+		if err == queue.ErrEmpty {
+			// Nothing to do yet; the source isn't exhausted, just idle.
+			continue
+		}
+		if err != nil || !ok {
 			fmt.Printf("[Worker %d] Stopping\n", id)
 			return
+		}
+
+		if _, dup := visited.LoadOrStore(canonicalize(job.URL), true); dup {
+			if err := src.Ack(ctx, job); err != nil {
+				fmt.Printf("[Worker %d] Error acking %s: %v\n", id, job.URL, err)
+			}
+			continue
+		}
 
-		// Case 2: Receive a job (URL) from the jobs channel
-		case url, ok := <-jobs:
-			// If the channel is closed (no more jobs), exit the worker
-			if !ok {
-				return
+		if !pol.Allowed(job.URL) {
+			fmt.Printf("[Worker %d] Skipping %s: disallowed by robots.txt\n", id, job.URL)
+			if err := src.Ack(ctx, job); err != nil {
+				fmt.Printf("[Worker %d] Error acking %s: %v\n", id, job.URL, err)
 			}
+			continue
+		}
+
+		host := hostOf(job.URL)
+		done, err := pol.Wait(ctx, host)
+		if err != nil {
+			// ctx was canceled; Next will report the shutdown next iteration.
+			if nackErr := src.Nack(ctx, job); nackErr != nil {
+				fmt.Printf("[Worker %d] Error requeueing %s: %v\n", id, job.URL, nackErr)
+			}
+			continue
+		}
 
-			// Fetch the content of the URL with up to 3 retries
-			body, err := fetcher.FetchWithRetry(url, 3)
-			if err != nil {
-				// If an error occurs (e.g., network issue or non-200 response after retries),
-				// send an error message to the results channel
-				results <- fmt.Sprintf("Worker %d: Error fetching %s: %v", id, url, err)
-			} else {
-				// On success, send the URL and the length of the fetched body
-				results <- fmt.Sprintf("Worker %d: Fetched %s, length: %d", id, url, len(body))
+		resp, err := fetcher.FetchWithRetry(ctx, job.URL, retryPolicy)
+		done()
+		if err != nil {
+			fmt.Printf("[Worker %d] Error fetching %s: %v\n", id, job.URL, err)
+			if nackErr := src.Nack(ctx, job); nackErr != nil {
+				fmt.Printf("[Worker %d] Error requeueing %s: %v\n", id, job.URL, nackErr)
 			}
+			continue
 		}
+		pol.Update(host, fetcher.ParseRetryAfter(resp.Headers))
+
+		result, err := extractor.Extract(job.URL, resp.StatusCode, resp.Headers, []byte(resp.Body))
+		if err != nil {
+			fmt.Printf("[Worker %d] Error parsing %s: %v\n", id, job.URL, err)
+			if err := src.Ack(ctx, job); err != nil {
+				fmt.Printf("[Worker %d] Error acking %s: %v\n", id, job.URL, err)
+			}
+			continue
+		}
+		if err := sink.Publish(ctx, result); err != nil {
+			fmt.Printf("[Worker %d] Error publishing result for %s: %v\n", id, job.URL, err)
+		}
+
+		// Discovered links are enqueued before the job is Ack'd: on
+		// backends like queue.Memory, Ack retires this job's pending
+		// credit, which - if it's the last one outstanding - lets the
+		// jobs channel close. Enqueueing first guarantees any child this
+		// job produces is already accounted for when that happens.
+		if job.Depth < cfg.MaxDepth {
+			for _, link := range result.Links {
+				if cfg.SameHostOnly && !sameHost(job.URL, link) {
+					continue
+				}
+				if _, seen := visited.Load(canonicalize(link)); seen {
+					continue
+				}
+				if err := src.Enqueue(ctx, queue.Job{URL: link, Depth: job.Depth + 1}); err != nil {
+					fmt.Printf("[Worker %d] Error enqueueing %s: %v\n", id, link, err)
+				}
+			}
+		}
+
+		if err := src.Ack(ctx, job); err != nil {
+			fmt.Printf("[Worker %d] Error acking %s: %v\n", id, job.URL, err)
+		}
+	}
+}
+
+// canonicalize normalizes a URL for visited-set comparisons by stripping
+// its fragment, so "/page#a" and "/page#b" are treated as the same page.
+func canonicalize(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+// hostOf returns the host component of rawURL, or rawURL itself if it
+// doesn't parse, so callers always have some key to group limits by.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
+
+// sameHost reports whether target points at the same host as base.
+func sameHost(base, target string) bool {
+	b, errBase := url.Parse(base)
+	t, errTarget := url.Parse(target)
+	if errBase != nil || errTarget != nil {
+		return false
 	}
+	return b.Host == t.Host
 }